@@ -23,18 +23,147 @@ import (
 	"reflect"
 	"sort"
 	"sync"
+	"unsafe"
 )
 
+// ErrMaxDepthExceeded is the sentinel wrapped by *MaxDepthExceededError once
+// TraverseConf.MaxDepth is exceeded. Use errors.Is to detect it regardless of
+// which level of the recursion produced it.
+var ErrMaxDepthExceeded = errors.New("dfpt: max depth exceeded")
+
+// MaxDepthExceededError reports the parentInfo chain active when
+// TraverseConf.MaxDepth was exceeded, deepest entry first, so callers can
+// tell which branch of the object graph ran away.
+type MaxDepthExceededError struct {
+	MaxDepth int
+	Chain    []*parentInfo
+}
+
+func (e *MaxDepthExceededError) Error() string {
+	return fmt.Sprintf("%v: limit=%d chain=%v", ErrMaxDepthExceeded, e.MaxDepth, e.Chain)
+}
+
+func (e *MaxDepthExceededError) Unwrap() error {
+	return ErrMaxDepthExceeded
+}
+
+// ErrCycleDetected is returned when TraverseConf.DetectCycles finds a value
+// again inside its own subtree (a value shared by sibling subtrees is not a
+// revisit) and the adapter has no ForCycle binding
+// and TraverseConf.CycleAction is CycleError.
+var ErrCycleDetected = errors.New("dfpt: cycle detected")
+
+// CycleAction controls what happens to a revisited value when
+// TraverseConf.DetectCycles is set and the adapter defines no ForCycle
+// binding for it.
+type CycleAction int
+
+const (
+	// CycleSkip stops descending into the repeated value without error.
+	CycleSkip CycleAction = iota
+	// CycleError aborts the traversal with ErrCycleDetected.
+	CycleError
+)
+
+// cycleKey identifies a reflect.Value for cycle detection: the address it
+// points to (or, for a Slice, its backing array) plus its static type, since
+// distinct types can share the same address (e.g. a struct and its first
+// field).
+type cycleKey struct {
+	ptr unsafe.Pointer
+	typ reflect.Type
+}
+
+// cycleKeyOf returns the identity key used for cycle detection and whether
+// val's kind carries a stable enough address to track. Value kinds without
+// one (e.g. a non-nil interface wrapping a struct) are never considered
+// revisited.
+func cycleKeyOf(val reflect.Value) (cycleKey, bool) {
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan:
+		if val.IsNil() {
+			return cycleKey{}, false
+		}
+		return cycleKey{ptr: unsafe.Pointer(val.Pointer()), typ: val.Type()}, true
+	case reflect.Slice:
+		if val.IsNil() {
+			return cycleKey{}, false
+		}
+		return cycleKey{ptr: unsafe.Pointer(val.Pointer()), typ: val.Type()}, true
+	case reflect.Interface:
+		if val.IsNil() {
+			return cycleKey{}, false
+		}
+		return cycleKeyOf(val.Elem())
+	default:
+		return cycleKey{}, false
+	}
+}
+
+// containsCycleKey reports whether key is already among keys, used to tell a
+// value this same _traverse call has already unwrapped through (e.g. an
+// interface and the pointer it wraps, which cycleKeyOf resolves to the same
+// key) from one recorded by an ancestor - only the latter is a genuine
+// revisit. Keys recorded by an earlier sibling are already gone by then.
+func containsCycleKey(keys []cycleKey, key cycleKey) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// travState carries the bookkeeping a single Traverse/TraverseMutable call
+// threads through the recursive _call/_traverse helpers. It is created fresh
+// per top-level call and is never exposed to adapters, unlike TravContext.
+type travState struct {
+	visited map[cycleKey]struct{} // DetectCycles bookkeeping, nil when disabled
+	mutable bool                  // true under TraverseMutable: val is addressable and may be rewritten
+}
+
+// ErrMutableRequiresPointer is returned by TraverseMutable when given
+// anything other than a non-nil pointer, since addressability can only be
+// guaranteed starting from one.
+var ErrMutableRequiresPointer = errors.New("dfpt: TraverseMutable requires a non-nil pointer")
+
+// parseTransformReturns reads the (newVal interface{}, replace, goin bool,
+// err error) tuple returned by a ForTransformXxx binding.
+func parseTransformReturns(outs []reflect.Value) (newVal interface{}, replace, goin bool, err error) {
+	newVal = outs[0].Interface()
+	replace = outs[1].Bool()
+	goin = outs[2].Bool()
+	if e, _ := outs[3].Interface().(error); e != nil {
+		err = e
+	}
+	return
+}
+
+// _cycle runs when a revisit is detected: it prefers the adapter's ForCycle
+// binding if defined, falling back to TraverseConf.CycleAction otherwise.
+func (t *Traveller) _cycle(ctx *TravContext, parent *parentInfo, val reflect.Value) error {
+	if method, ok := t.shortcuts[ForCycle]; ok {
+		outs := method.Call(parent.callIns(ctx, val))
+		_, err := ForCycle.parseReturns(outs)
+		return err
+	}
+	if t.conf != nil && t.conf.CycleAction == CycleError {
+		return fmt.Errorf("%w: type:%s", ErrCycleDetected, val.Type())
+	}
+	return nil
+}
+
 type Traveller struct {
-	adapter         reflect.Value
-	conf            *TraverseConf
-	prefixes        ItemTypes                      // group bindings run before all individually bindings
-	suffixes        ItemTypes                      // group bindings run after all individually bindings
-	shortcuts       map[ItemType]reflect.Value     // group bindings(ForNilPtr/ForIntX/ForUintX/ForAllKinds) -> binding methods
-	typeMethods     map[reflect.Type]reflect.Value // type -> method
-	kindMethods     map[reflect.Kind]reflect.Value // kind -> method
-	typeOrder       orderItems                     // all type list in order (tag order or declare order)
-	structTypeCache sync.Map
+	adapter          reflect.Value
+	conf             *TraverseConf
+	prefixes         ItemTypes                      // group bindings run before all individually bindings
+	suffixes         ItemTypes                      // group bindings run after all individually bindings
+	shortcuts        map[ItemType]reflect.Value     // group bindings(ForNilPtr/ForIntX/ForUintX/ForAllKinds/ForCycle) -> binding methods
+	typeMethods      map[reflect.Type]reflect.Value // type -> method
+	kindMethods      map[reflect.Kind]reflect.Value // kind -> method
+	transformMethods map[reflect.Kind]reflect.Value // kind -> ForTransformXxx method, only consulted by TraverseMutable
+	typeOrder        orderItems                     // all type list in order (tag order or declare order)
+	structTypeCache  sync.Map
 }
 
 func NewTraveller(adapter interface{}, config ...*TraverseConf) (*Traveller, error) {
@@ -47,6 +176,7 @@ func NewTraveller(adapter interface{}, config ...*TraverseConf) (*Traveller, err
 	shortcuts := make(map[ItemType]reflect.Value)
 	typeMethods := make(map[reflect.Type]reflect.Value)
 	kindMethods := make(map[reflect.Kind]reflect.Value)
+	transformMethods := make(map[reflect.Kind]reflect.Value)
 	for i := 0; i < aptType.NumMethod(); i++ {
 		m := aptType.Method(i)
 		itype, inKind, ok := Unknown.Which(m.Name)
@@ -85,14 +215,19 @@ func NewTraveller(adapter interface{}, config ...*TraverseConf) (*Traveller, err
 				k: inKind,
 			})
 			kindMethods[inKind] = aptVal.Method(i)
-		case ForNilPtr, ForIntX, ForUintX, ForAllKinds:
+		case ForTransform:
+			if _, exist := transformMethods[inKind]; exist {
+				return nil, fmt.Errorf("duplicated transform binding function %s found for Kind:%s", m.Name, inKind.String())
+			}
+			transformMethods[inKind] = aptVal.Method(i)
+		case ForNilPtr, ForIntX, ForUintX, ForAllKinds, ForCycle:
 			if _, exist := shortcuts[itype]; exist {
 				return nil, fmt.Errorf("duplicated binding function %s found", m.Name)
 			}
 			shortcuts[itype] = aptVal.Method(i)
 		}
 	}
-	if len(items) == 0 && len(shortcuts) == 0 {
+	if len(items) == 0 && len(shortcuts) == 0 && len(transformMethods) == 0 {
 		return nil, errors.New("no available binding function found")
 	}
 	sort.Sort(items)
@@ -113,14 +248,15 @@ func NewTraveller(adapter interface{}, config ...*TraverseConf) (*Traveller, err
 		sort.Sort(suffixs)
 	}
 	return &Traveller{
-		adapter:     aptVal,
-		conf:        conf,
-		prefixes:    prefixs,
-		suffixes:    suffixs,
-		shortcuts:   shortcuts,
-		typeMethods: typeMethods,
-		kindMethods: kindMethods,
-		typeOrder:   items,
+		adapter:          aptVal,
+		conf:             conf,
+		prefixes:         prefixs,
+		suffixes:         suffixs,
+		shortcuts:        shortcuts,
+		typeMethods:      typeMethods,
+		kindMethods:      kindMethods,
+		transformMethods: transformMethods,
+		typeOrder:        items,
 	}, nil
 }
 
@@ -139,12 +275,135 @@ func (t *Traveller) String() string {
 		adapterStr, t.prefixes, t.suffixes, len(t.typeMethods), len(t.kindMethods), []orderItem(t.typeOrder))
 }
 
-func (t *Traveller) _call(ctx *TravContext, parent *parentInfo, val reflect.Value) (goin, reEnter bool,
+// _containerSize computes the child count (and, for structs, the ordered
+// property list) used to build the parentInfo for a value entered as a
+// container, regardless of whether it was reached through a regular
+// ForContainerXxx binding or, under TraverseMutable, a
+// ForTransformXxx binding. A non-nil interface counts as a single child, its
+// dynamic value, exactly like a non-nil pointer.
+func (t *Traveller) _containerSize(kind reflect.Kind, val reflect.Value) (int, []Property) {
+	switch kind {
+	case reflect.Array:
+		return val.Len(), nil
+	case reflect.Slice:
+		if !val.IsNil() {
+			return val.Len(), nil
+		}
+	case reflect.Map:
+		if !val.IsNil() {
+			return val.Len() << 1, nil
+		}
+	case reflect.Struct:
+		return t._structProperties(val)
+	case reflect.Ptr, reflect.Interface:
+		if !val.IsNil() {
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// _isContainerKind reports whether a value of kind structurally has children
+// to recurse into once a ForTransformXxx binding has returned goin=true. This
+// is consulted only by the mutable-transform dispatch, which has a single
+// calling convention for every kind and so cannot tell container from leaf by
+// itype the way the ForKind/ForContainer dispatch does; reflect.Interface is
+// included alongside whatever _containers lists because a non-nil interface's
+// dynamic value is its one child regardless of which binding matched it.
+func _isContainerKind(kind reflect.Kind) bool {
+	if kind == reflect.Interface {
+		return true
+	}
+	_, ok := _containers[kind]
+	return ok
+}
+
+// _isTransformBinding reports whether binding is one of the adapter's
+// registered ForTransformXxx methods, as opposed to a regular
+// ForKind/ForContainer/ForImpl one.
+func (t *Traveller) _isTransformBinding(binding reflect.Value) bool {
+	for _, m := range t.transformMethods {
+		if m == binding {
+			return true
+		}
+	}
+	return false
+}
+
+// _checkMaxDepth reports the depth parent's next child would be entered at,
+// erroring once TraverseConf.MaxDepth is exceeded. Shared by every place a
+// value is descended into, whether through an explicit container binding or
+// through PtrAutoGoIn/InterfaceAutoGoIn re-entering without one.
+func (t *Traveller) _checkMaxDepth(parent *parentInfo) (int, error) {
+	depth := parent.nextDepth()
+	if t.conf != nil && t.conf.MaxDepth > 0 && depth > t.conf.MaxDepth {
+		return depth, &MaxDepthExceededError{MaxDepth: t.conf.MaxDepth, Chain: []*parentInfo{parent}}
+	}
+	return depth, nil
+}
+
+// _enterContainer builds the parentInfo for descending into val as a
+// container of the given kind, applying the MaxDepth guard shared by every
+// entry path.
+func (t *Traveller) _enterContainer(parent *parentInfo, kind reflect.Kind, val reflect.Value,
+	binding reflect.Value) (*parentInfo, error) {
+	depth, err := t._checkMaxDepth(parent)
+	if err != nil {
+		return nil, err
+	}
+	size, fields := t._containerSize(kind, val)
+	return &parentInfo{
+		depth:        depth,
+		value:        val,
+		size:         size,
+		offset:       -1,
+		structFields: fields,
+		binding:      binding,
+	}, nil
+}
+
+func (t *Traveller) _call(ctx *TravContext, parent *parentInfo, val reflect.Value, st *travState) (goin, reEnter bool,
 	info *parentInfo, newVal reflect.Value, err error) {
 	if !val.IsValid() {
 		return false, false, nil, reflect.Value{}, errors.New("invalid value")
 	}
 
+	// mutable transform: a kind opted into in-place rewriting via
+	// ForTransformXxx takes over for it under TraverseMutable; every other
+	// kind falls through unaffected to its regular binding below.
+	if st != nil && st.mutable {
+		if method, ok := t.transformMethods[val.Kind()]; ok {
+			ins := append(parent.callIns(ctx, val), reflect.ValueOf(val))
+			transformed, replace, goin, terr := parseTransformReturns(method.Call(ins))
+			if terr != nil {
+				return false, false, nil, reflect.Value{}, terr
+			}
+			if replace {
+				rv := reflect.ValueOf(transformed)
+				if !rv.IsValid() || !rv.Type().AssignableTo(val.Type()) {
+					return false, false, nil, reflect.Value{},
+						fmt.Errorf("transformed value of type %T is not assignable to %s", transformed, val.Type())
+				}
+				if !val.CanSet() {
+					return false, false, nil, reflect.Value{},
+						fmt.Errorf("value of type %s is not addressable for TraverseMutable", val.Type())
+				}
+				val.Set(rv)
+			}
+			if !goin {
+				return false, false, nil, reflect.Value{}, nil
+			}
+			if _isContainerKind(val.Kind()) {
+				info, err = t._enterContainer(parent, val.Kind(), val, method)
+				if err != nil {
+					return false, false, nil, reflect.Value{}, err
+				}
+				return true, false, info, reflect.Value{}, nil
+			}
+			return false, false, nil, reflect.Value{}, nil
+		}
+	}
+
 	// prefix shortcuts
 	for _, itype := range t.prefixes {
 		if itype.MatchValue(val) {
@@ -172,34 +431,10 @@ func (t *Traveller) _call(ctx *TravContext, parent *parentInfo, val reflect.Valu
 			if !ok || !fVal.IsValid() {
 				panic(fmt.Errorf("matching %d item %s, but function not found by Kind:%s", i, item, kind.String()))
 			}
-			if _, isContainer := _containers[kind]; isContainer {
-				var size int
-				var fields []Property
-				switch kind {
-				case reflect.Array:
-					size = val.Len()
-				case reflect.Slice:
-					if !val.IsNil() {
-						size = val.Len()
-					}
-				case reflect.Map:
-					if !val.IsNil() {
-						size = val.Len() << 1
-					}
-				case reflect.Struct:
-					size, fields = t._structProperties(val)
-				case reflect.Ptr:
-					if !val.IsNil() {
-						size = 1
-					}
-				}
-				info = &parentInfo{
-					depth:        parent.nextDepth(),
-					value:        val,
-					size:         size,
-					offset:       -1,
-					structFields: fields,
-					binding:      fVal,
+			if itype == ForContainer {
+				info, err = t._enterContainer(parent, kind, val, fVal)
+				if err != nil {
+					return false, false, nil, reflect.Value{}, err
 				}
 				outs = fVal.Call(parent.startContainerIns(ctx, info, val))
 			} else {
@@ -219,6 +454,28 @@ func (t *Traveller) _call(ctx *TravContext, parent *parentInfo, val reflect.Valu
 		// no callback for Ptr
 		if val.Type().Kind() == reflect.Ptr {
 			if val.IsNil() == false {
+				// the auto-goin re-enter below bypasses _enterContainer, so the
+				// depth guard that would normally apply there has to be checked
+				// here instead, against the element about to be re-entered
+				if _, err = t._checkMaxDepth(parent); err != nil {
+					return false, false, nil, reflect.Value{}, err
+				}
+				newVal = val.Elem()
+				return false, true, parent, newVal, nil
+			} else {
+				return false, false, parent, reflect.Value{}, nil
+			}
+		}
+	}
+	if t.conf != nil && t.conf.InterfaceAutoGoIn {
+		// no callback for a non-nil interface: re-enter with its dynamic value
+		if val.Kind() == reflect.Interface {
+			if val.IsNil() == false {
+				// same reasoning as the Ptr case above: this re-enter never
+				// reaches _enterContainer, so guard depth here
+				if _, err = t._checkMaxDepth(parent); err != nil {
+					return false, false, nil, reflect.Value{}, err
+				}
 				newVal = val.Elem()
 				return false, true, parent, newVal, nil
 			} else {
@@ -264,7 +521,20 @@ func (t *Traveller) _structProperties(val reflect.Value) (int, []Property) {
 	return len(ps), ps
 }
 
-func (t *Traveller) _traverse(ctx *TravContext, parent *parentInfo, val reflect.Value) error {
+// _wrapMaxDepth threads the still-unwinding *MaxDepthExceededError through
+// next, the parentInfo of the frame currently returning, so Chain ends up
+// describing the whole offending path once it reaches the caller of Traverse.
+func _wrapMaxDepth(err error, next *parentInfo) error {
+	if mde, ok := err.(*MaxDepthExceededError); ok {
+		// the failing frame's parent is already the first entry of Chain
+		if n := len(mde.Chain); n == 0 || mde.Chain[n-1] != next {
+			mde.Chain = append(mde.Chain, next)
+		}
+	}
+	return err
+}
+
+func (t *Traveller) _traverse(ctx *TravContext, parent *parentInfo, val reflect.Value, st *travState) error {
 	if !val.IsValid() {
 		return fmt.Errorf("invalid value in _traverse(parent:%s, val:%s)", parent, val.String())
 	}
@@ -273,11 +543,48 @@ func (t *Traveller) _traverse(ctx *TravContext, parent *parentInfo, val reflect.
 	var err error
 	oldVal := val
 	var newVal reflect.Value
+	var visitedKeys []cycleKey
+	if st != nil && st.visited != nil {
+		defer func() {
+			for _, key := range visitedKeys {
+				delete(st.visited, key)
+			}
+		}()
+	}
 	for {
-		goin, reEnter, next, newVal, err = t._call(ctx, parent, oldVal)
+		// cycle detection: a revisit never goes in again, regardless of
+		// binding. Checked here rather than inside _call so that
+		// PtrAutoGoIn/InterfaceAutoGoIn unwrapping the same identity across
+		// several loop iterations - e.g. a non-nil interface wrapping a
+		// pointer, where cycleKeyOf resolves both the interface and the
+		// pointer to the same key - isn't mistaken for a revisit: visitedKeys
+		// only holds keys this same call has already unwrapped through, so a
+		// match against it is expected, not a cycle.
+		if st != nil && st.visited != nil {
+			if key, trackable := cycleKeyOf(oldVal); trackable {
+				if _, seen := st.visited[key]; seen && !containsCycleKey(visitedKeys, key) {
+					return t._cycle(ctx, parent, oldVal)
+				}
+			}
+		}
+		goin, reEnter, next, newVal, err = t._call(ctx, parent, oldVal, st)
 		if err != nil {
 			return err
 		}
+		// oldVal has cleared the revisit check above; now that we know it's
+		// being descended into, record it so a deeper revisit is caught.
+		// This has to happen per iteration rather than once after the loop:
+		// PtrAutoGoIn and InterfaceAutoGoIn resolve oldVal to its unwrapped
+		// element (e.g. a struct) before the loop breaks, and that element
+		// carries no trackable address of its own, so recording only the
+		// final oldVal would never mark the pointer/interface itself as
+		// visited.
+		if (reEnter || goin) && st != nil && st.visited != nil {
+			if key, trackable := cycleKeyOf(oldVal); trackable && !containsCycleKey(visitedKeys, key) {
+				st.visited[key] = struct{}{}
+				visitedKeys = append(visitedKeys, key)
+			}
+		}
 		if reEnter {
 			if !newVal.IsValid() {
 				panic(fmt.Errorf("reenter need a valid value, oldVal:%s", oldVal))
@@ -298,8 +605,8 @@ func (t *Traveller) _traverse(ctx *TravContext, parent *parentInfo, val reflect.
 		for i := 0; i < next.size; i++ {
 			child := oldVal.Index(i)
 			next.offset = i
-			if err = t._traverse(ctx, next, child); err != nil {
-				return err
+			if err = t._traverse(ctx, next, child, st); err != nil {
+				return _wrapMaxDepth(err, next)
 			}
 		}
 	case reflect.Map:
@@ -311,13 +618,24 @@ func (t *Traveller) _traverse(ctx *TravContext, parent *parentInfo, val reflect.
 			for i := 0; i < len(keys); i++ {
 				// stack value for map: idx%2==0 is the key of map, idx%2==1 is the value of map
 				next.offset = i << 1
-				if err = t._traverse(ctx, next, keys[i]); err != nil {
-					return err
+				if err = t._traverse(ctx, next, keys[i], st); err != nil {
+					return _wrapMaxDepth(err, next)
 				}
 				value := oldVal.MapIndex(keys[i])
+				if st != nil && st.mutable {
+					// MapIndex is never addressable; copy into a settable
+					// temporary so a ForTransformXxx binding can rewrite it,
+					// then re-insert it below.
+					tmp := reflect.New(value.Type()).Elem()
+					tmp.Set(value)
+					value = tmp
+				}
 				next.offset = i<<1 + 1
-				if err = t._traverse(ctx, next, value); err != nil {
-					return err
+				if err = t._traverse(ctx, next, value, st); err != nil {
+					return _wrapMaxDepth(err, next)
+				}
+				if st != nil && st.mutable {
+					oldVal.SetMapIndex(keys[i], value)
 				}
 			}
 		}
@@ -329,22 +647,26 @@ func (t *Traveller) _traverse(ctx *TravContext, parent *parentInfo, val reflect.
 			}
 			fieldVal := oldVal.Field(field.Index)
 			next.offset = i
-			if err = t._traverse(ctx, next, fieldVal); err != nil {
-				return err
+			if err = t._traverse(ctx, next, fieldVal, st); err != nil {
+				return _wrapMaxDepth(err, next)
 			}
 		}
-	case reflect.Ptr:
+	case reflect.Ptr, reflect.Interface:
 		if next.size > 0 {
 			elem := oldVal.Elem()
 			next.offset = 0
-			if err = t._traverse(ctx, next, elem); err != nil {
-				return err
+			if err = t._traverse(ctx, next, elem, st); err != nil {
+				return _wrapMaxDepth(err, next)
 			}
 		}
 	default:
 		panic("unknown status")
 	}
-	if t.conf != nil && t.conf.ContainerEnd {
+	// a container entered via a ForTransformXxx binding under TraverseMutable
+	// has no end-of-container counterpart: its binding takes a different
+	// argument list (and return tuple) than ForContainer expects, so calling
+	// it again here would panic on the mismatched arg count.
+	if t.conf != nil && t.conf.ContainerEnd && !t._isTransformBinding(next.binding) {
 		outs := next.binding.Call(parent.endContainerIns(ctx, next, oldVal))
 		_, err = ForContainer.parseReturns(outs)
 		if err != nil {
@@ -359,5 +681,27 @@ func (t *Traveller) Traverse(ctx *TravContext, obj interface{}) error {
 	if !val.IsValid() {
 		return nil
 	}
-	return t._traverse(ctx, nil, val)
+	var st *travState
+	if t.conf != nil && t.conf.DetectCycles {
+		st = &travState{visited: make(map[cycleKey]struct{})}
+	}
+	return t._traverse(ctx, nil, val, st)
+}
+
+// TraverseMutable walks ptrToObj like Traverse, but passes addressable
+// values to the adapter and allows a ForTransformXxx binding to rewrite them
+// in place: if it returns replace==true, the (possibly container) value is
+// written back via reflect.Value.Set before recursion continues into the
+// (possibly replaced) value. ptrToObj must be a non-nil pointer, since that
+// is the only way to guarantee the root value is addressable.
+func (t *Traveller) TraverseMutable(ctx *TravContext, ptrToObj interface{}) error {
+	val := reflect.ValueOf(ptrToObj)
+	if !val.IsValid() || val.Kind() != reflect.Ptr || val.IsNil() {
+		return ErrMutableRequiresPointer
+	}
+	st := &travState{mutable: true}
+	if t.conf != nil && t.conf.DetectCycles {
+		st.visited = make(map[cycleKey]struct{})
+	}
+	return t._traverse(ctx, nil, val, st)
 }
@@ -19,6 +19,7 @@ package dfpt
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"reflect"
 	"sort"
@@ -355,6 +356,286 @@ func (w wParser) ForContainerSlice(ctx *TravContext, depth, indexOfParent, size
 	return false, nil
 }
 
+type cycleNode struct {
+	Name string
+	Next *cycleNode
+}
+
+type cycleParser struct {
+	parser1
+}
+
+func (p cycleParser) ForCycle(_ *TravContext, depth, indexOfParent int, name string, property interface{}) error {
+	fmt.Printf("ForCycle(depth:%d index:%d name:%s prop:%s)\n", depth, indexOfParent, name, reflect.TypeOf(property))
+	return nil
+}
+
+func TestCycleDetection(t *testing.T) {
+	n := &cycleNode{Name: "self"}
+	n.Next = n
+
+	p := cycleParser{}
+	tr, err := NewTraveller(p, &TraverseConf{PtrAutoGoIn: true, DetectCycles: true, IgnoreMissedBinding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tr.Traverse(NewContext(), n); err != nil {
+		t.Fatalf("expected the self-reference to be caught by ForCycle, got error: %v", err)
+	}
+
+	// without a ForCycle binding, a revisit falls back to TraverseConf.CycleAction
+	p1 := parser1{}
+	tr1, err := NewTraveller(p1, &TraverseConf{
+		PtrAutoGoIn: true, DetectCycles: true, CycleAction: CycleError, IgnoreMissedBinding: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tr1.Traverse(NewContext(), n); !errors.Is(err, ErrCycleDetected) {
+		t.Fatalf("expected ErrCycleDetected, got %v", err)
+	}
+}
+
+func TestMaxDepthExceeded(t *testing.T) {
+	head := &cycleNode{Name: "0"}
+	cur := head
+	for i := 1; i < 10; i++ {
+		next := &cycleNode{Name: fmt.Sprintf("%d", i)}
+		cur.Next = next
+		cur = next
+	}
+
+	p := cycleParser{}
+	tr, err := NewTraveller(p, &TraverseConf{PtrAutoGoIn: true, MaxDepth: 3, IgnoreMissedBinding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = tr.Traverse(NewContext(), head)
+	var mde *MaxDepthExceededError
+	if !errors.As(err, &mde) {
+		t.Fatalf("expected *MaxDepthExceededError, got %v", err)
+	}
+	t.Log(mde)
+}
+
+type autoGoInCycleParser struct {
+	parser0
+}
+
+func (p autoGoInCycleParser) ForCycle(_ *TravContext, depth, indexOfParent int, name string, property interface{}) error {
+	fmt.Printf("ForCycle(depth:%d index:%d name:%s prop:%s)\n", depth, indexOfParent, name, reflect.TypeOf(property))
+	return nil
+}
+
+// TestCycleDetectionAutoGoIn covers the case the original request cites: no
+// ForContainerPtr binding at all, so the Ptr is only ever seen through the
+// PtrAutoGoIn re-enter path rather than an explicit container boundary.
+// cycleParser (used by TestCycleDetection) inherits ForContainerPtr from
+// parser1 and so doesn't exercise this path.
+func TestCycleDetectionAutoGoIn(t *testing.T) {
+	n := &cycleNode{Name: "self"}
+	n.Next = n
+
+	p := autoGoInCycleParser{}
+	tr, err := NewTraveller(p, &TraverseConf{PtrAutoGoIn: true, DetectCycles: true, IgnoreMissedBinding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tr.Traverse(NewContext(), n); err != nil {
+		t.Fatalf("expected the self-reference to be caught by ForCycle, got error: %v", err)
+	}
+}
+
+type mutNode struct {
+	Val  int
+	Next *mutNode
+}
+
+type mutParser struct {
+	parser1
+}
+
+func (p mutParser) ForTransformInt(_ *TravContext, depth, indexOfParent int, name string, property interface{},
+	val reflect.Value) (newVal interface{}, replace, goin bool, err error) {
+	return property.(int) * 2, true, false, nil
+}
+
+func TestTraverseMutable(t *testing.T) {
+	n := &mutNode{Val: 5, Next: &mutNode{Val: 10}}
+
+	p := mutParser{}
+	tr, err := NewTraveller(p, &TraverseConf{PtrAutoGoIn: true, IgnoreMissedBinding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tr.TraverseMutable(NewContext(), n); err != nil {
+		t.Fatal(err)
+	}
+	if n.Val != 10 || n.Next.Val != 20 {
+		t.Fatalf("expected {10 {20 <nil>}}, got %+v %+v", n, n.Next)
+	}
+
+	if err = tr.TraverseMutable(NewContext(), mutNode{}); err != ErrMutableRequiresPointer {
+		t.Fatalf("expected ErrMutableRequiresPointer, got %v", err)
+	}
+}
+
+type mutSliceHolder struct {
+	Items []int
+}
+
+type mutContainerParser struct {
+	parser1
+}
+
+func (p mutContainerParser) ForTransformSlice(_ *TravContext, depth, indexOfParent int, name string, property interface{},
+	val reflect.Value) (newVal interface{}, replace, goin bool, err error) {
+	return nil, false, true, nil
+}
+
+func (p mutContainerParser) ForAssign0(_ *TravContext, depth, indexOfParent int, name string, property int) error {
+	return nil
+}
+
+func TestTraverseMutableContainerEnd(t *testing.T) {
+	h := &mutSliceHolder{Items: []int{1, 2, 3}}
+
+	p := mutContainerParser{}
+	tr, err := NewTraveller(p, &TraverseConf{PtrAutoGoIn: true, ContainerEnd: true, IgnoreMissedBinding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Items is entered as a container via ForTransformSlice; ContainerEnd
+	// must skip calling it a second time (its arg list doesn't match what
+	// ForContainer expects) while still firing for the struct, which was
+	// entered through the regular ForContainerStruct binding.
+	if err = tr.TraverseMutable(NewContext(), h); err != nil {
+		t.Fatalf("expected ContainerEnd to be skipped for the transform-entered slice, got: %v", err)
+	}
+}
+
+type ifaceParser struct {
+	parser0
+	ints []int
+}
+
+func (p *ifaceParser) ForContainerSlice(_ *TravContext, depth, indexOfParent, size int, startOrEnd bool, name string,
+	property interface{}) (bool, error) {
+	fmt.Printf("ForContainerSlice(depth:%d index:%d size:%d start:%t name:%s)\n", depth, indexOfParent, size, startOrEnd, name)
+	return true, nil
+}
+
+func (p *ifaceParser) ForAssign0(_ *TravContext, depth, indexOfParent int, name string, property int) error {
+	p.ints = append(p.ints, property)
+	return nil
+}
+
+func TestInterfaceAutoGoIn(t *testing.T) {
+	items := []interface{}{1, 2, 3}
+
+	p := &ifaceParser{}
+	tr, err := NewTraveller(p, &TraverseConf{PtrAutoGoIn: true, InterfaceAutoGoIn: true, IgnoreMissedBinding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(tr)
+	if err = tr.Traverse(NewContext(), items); err != nil {
+		t.Fatal(err)
+	}
+	// each []interface{} element has no explicit binding, so InterfaceAutoGoIn
+	// must have unwrapped it to its dynamic int before ForAssign0 saw it
+	if !reflect.DeepEqual(p.ints, []int{1, 2, 3}) {
+		t.Fatalf("expected InterfaceAutoGoIn to surface the unwrapped ints [1 2 3], got %v", p.ints)
+	}
+}
+
+type ifaceContainerParser struct {
+	parser0
+	seen []interface{}
+}
+
+func (p *ifaceContainerParser) ForContainerInterface(_ *TravContext, depth, indexOfParent, size int, startOrEnd bool,
+	name string, property interface{}) (bool, error) {
+	return true, nil
+}
+
+func (p *ifaceContainerParser) ForAssign0(_ *TravContext, depth, indexOfParent int, name string, property int) error {
+	p.seen = append(p.seen, property)
+	return nil
+}
+
+type ifaceHolder struct {
+	V interface{}
+}
+
+func TestForContainerInterface(t *testing.T) {
+	h := &ifaceHolder{V: 42}
+
+	p := &ifaceContainerParser{}
+	tr, err := NewTraveller(p, &TraverseConf{PtrAutoGoIn: true, IgnoreMissedBinding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tr.Traverse(NewContext(), h); err != nil {
+		t.Fatal(err)
+	}
+	// with an explicit ForContainerInterface binding, V is entered as a
+	// container (not auto-unwrapped) and its dynamic value is recursed into
+	if !reflect.DeepEqual(p.seen, []interface{}{42}) {
+		t.Fatalf("expected ForContainerInterface to descend into the dynamic value [42], got %v", p.seen)
+	}
+}
+
+type kindLeafHolder struct {
+	I interface{}
+	C chan int
+	F func()
+}
+
+type kindLeafParser struct {
+	parser0
+	interfaces []interface{}
+	chans      int
+	funcs      int
+}
+
+func (p *kindLeafParser) ForKindInterface(_ *TravContext, depth, indexOfParent int, name string, property interface{}) error {
+	p.interfaces = append(p.interfaces, property)
+	return nil
+}
+
+func (p *kindLeafParser) ForKindChan(_ *TravContext, depth, indexOfParent int, name string, property interface{}) error {
+	p.chans++
+	return nil
+}
+
+func (p *kindLeafParser) ForKindFunc(_ *TravContext, depth, indexOfParent int, name string, property interface{}) error {
+	p.funcs++
+	return nil
+}
+
+func TestForKindInterfaceChanFunc(t *testing.T) {
+	h := &kindLeafHolder{I: "hello", C: make(chan int), F: func() {}}
+
+	p := &kindLeafParser{}
+	tr, err := NewTraveller(p, &TraverseConf{PtrAutoGoIn: true, IgnoreMissedBinding: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = tr.Traverse(NewContext(), h); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.interfaces) != 1 || p.interfaces[0] != "hello" {
+		t.Fatalf("expected ForKindInterface to see [hello], got %v", p.interfaces)
+	}
+	if p.chans != 1 {
+		t.Fatalf("expected ForKindChan to be called once, got %d", p.chans)
+	}
+	if p.funcs != 1 {
+		t.Fatalf("expected ForKindFunc to be called once, got %d", p.funcs)
+	}
+}
+
 func TestIntAssign(t *testing.T) {
 	type int16th int16
 	type inth int